@@ -2,38 +2,75 @@ package hana
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"database/sql"
+	"errors"
 	"fmt"
+	"net/url"
 	"strings"
+	"unicode"
 
-	"github.com/hashicorp/vault/api"
+	"github.com/SAP/go-hdb/driver"
+	"github.com/hashicorp/vault/sdk/database/dbplugin/v5"
 	"github.com/hashicorp/vault/sdk/database/helper/connutil"
 	"github.com/hashicorp/vault/sdk/database/helper/credsutil"
 	"github.com/hashicorp/vault/sdk/database/helper/dbutil"
-	"github.com/hashicorp/vault/sdk/database/newdbplugin"
 	"github.com/hashicorp/vault/sdk/helper/dbtxn"
 	"github.com/hashicorp/vault/sdk/helper/strutil"
-
-	_ "github.com/SAP/go-hdb/driver"
 )
 
 const (
 	hanaTypeName        = "hdb"
 	maxIdentifierLength = 127
+
+	defaultRotateRootCredentialsSQL = `ALTER USER {{username}} PASSWORD "{{password}}"`
+
+	// defaultRestrictedUserCreationSQL precedes the operator's GRANT statements for a restricted user.
+	defaultRestrictedUserCreationSQL = `CREATE RESTRICTED USER {{name}} PASSWORD "{{password}}" VALID UNTIL '{{expiration}}'`
+
+	// dynamicUsernamePrefix is the prefix NewUser's generated usernames always carry.
+	dynamicUsernamePrefix = "V_"
+
+	minStaticRolePasswordLength = 8
+
+	authMethodPassword = "password"
+	authMethodX509     = "x509"
+	authMethodJWT      = "jwt"
+
+	// userTypePreamblePrefix marks a leading Statements.Commands entry as metadata, e.g. "-- vault:user_type=restricted".
+	userTypePreamblePrefix = "-- vault:user_type="
+
+	userTypeStandard   = "standard"
+	userTypeRestricted = "restricted"
 )
 
 // HANA is an implementation of Database interface
 type HANA struct {
 	*connutil.SQLConnectionProducer
+
+	// authMethod, when not "password", means db holds the active connection instead of
+	// SQLConnectionProducer's own pool.
+	authMethod string
+	db         *sql.DB
+
+	// defaultUserType and defaultRoles back the user_type preamble and {{roles}} template
+	// variable NewUser honors; see config keys "default_user_type" and "default_roles".
+	defaultUserType string
+	defaultRoles    []string
+
+	// passwordPolicy is the name of the Vault password policy governing static-role
+	// rotations on this connection, from config key "password_policy".
+	passwordPolicy string
 }
 
-var _ newdbplugin.Database = &HANA{}
+var _ dbplugin.Database = &HANA{}
 
 // New implements builtinplugins.BuiltinFactory
 func New() (interface{}, error) {
 	db := new()
 	// Wrap the plugin with middleware to sanitize errors
-	dbType := newdbplugin.NewDatabaseErrorSanitizerMiddleware(db, db.secretValues)
+	dbType := dbplugin.NewDatabaseErrorSanitizerMiddleware(db, db.secretValues)
 
 	return dbType, nil
 }
@@ -48,30 +85,199 @@ func new() *HANA {
 }
 
 func (h *HANA) secretValues() map[string]string {
-	return map[string]string{
+	vals := map[string]string{
 		h.Password: "[password]",
 	}
+
+	if h.authMethod != "" && h.authMethod != authMethodPassword {
+		if cert, ok := h.RawConfig["tls_certificate_pem"].(string); ok {
+			vals[cert] = "[tls_certificate_pem]"
+		}
+		if key, ok := h.RawConfig["tls_private_key_pem"].(string); ok {
+			vals[key] = "[tls_private_key_pem]"
+		}
+		if h.authMethod == authMethodJWT {
+			if jwt, ok := h.RawConfig["jwt"].(string); ok {
+				vals[jwt] = "[jwt]"
+			}
+		}
+	}
+
+	return vals
 }
 
-func (h *HANA) Initialize(ctx context.Context, req newdbplugin.InitializeRequest) (newdbplugin.InitializeResponse, error) {
-	conf, err := h.Init(ctx, req.Config, req.VerifyConnection)
-	if err != nil {
-		return newdbplugin.InitializeResponse{}, fmt.Errorf("error initializing db: %s", err)
+func (h *HANA) Initialize(ctx context.Context, req dbplugin.InitializeRequest) (dbplugin.InitializeResponse, error) {
+	authMethod, ok := req.Config["auth_method"].(string)
+	if !ok || authMethod == "" {
+		authMethod = authMethodPassword
+	}
+
+	h.applyRoleDefaults(req.Config)
+
+	switch authMethod {
+	case authMethodPassword:
+		h.Lock()
+		h.closeDBConn()
+		h.Unlock()
+
+		conf, err := h.Init(ctx, req.Config, req.VerifyConnection)
+		if err != nil {
+			return dbplugin.InitializeResponse{}, fmt.Errorf("error initializing db: %s", err)
+		}
+
+		h.authMethod = authMethodPassword
+
+		return dbplugin.InitializeResponse{
+			Config: conf,
+		}, nil
+
+	case authMethodX509, authMethodJWT:
+		db, err := h.openCertConnection(req.Config, authMethod)
+		if err != nil {
+			return dbplugin.InitializeResponse{}, fmt.Errorf("error initializing db: %w", err)
+		}
+
+		if req.VerifyConnection {
+			if err := db.PingContext(ctx); err != nil {
+				db.Close()
+				return dbplugin.InitializeResponse{}, fmt.Errorf("error verifying connection: %w", err)
+			}
+		}
+
+		h.Lock()
+		h.closeDBConn()
+		h.Unlock()
+
+		// SQLConnectionProducer.Close locks internally, the same mutex h.Lock/h.Unlock use
+		// (it's promoted from the embedded producer), so it must be called without holding it.
+		if err := h.SQLConnectionProducer.Close(); err != nil {
+			db.Close()
+			return dbplugin.InitializeResponse{}, fmt.Errorf("unable to close previous connection: %w", err)
+		}
+
+		h.Lock()
+		defer h.Unlock()
+
+		h.db = db
+		h.authMethod = authMethod
+		h.RawConfig = req.Config
+
+		return dbplugin.InitializeResponse{
+			Config: req.Config,
+		}, nil
+
+	default:
+		return dbplugin.InitializeResponse{}, fmt.Errorf("unsupported auth_method %q", authMethod)
+	}
+}
+
+// closeDBConn closes h.db, if set. The caller must already hold h.Lock(); it does not call
+// SQLConnectionProducer.Close, which takes the very same (promoted) lock internally.
+func (h *HANA) closeDBConn() error {
+	if h.db == nil {
+		return nil
 	}
 
-	return newdbplugin.InitializeResponse{
-		Config: conf,
-	}, nil
+	err := h.db.Close()
+	h.db = nil
+	return err
 }
 
-// Run instantiates a HANA object, and runs the RPC server for the plugin
-func Run(apiTLSConfig *api.TLSConfig) error {
-	dbType, err := New()
-	if err != nil {
+// Close closes whichever connection is currently active — SQLConnectionProducer's own pool
+// for password auth, or db for x509/jwt — so Vault's unmount/reload path doesn't leak either.
+func (h *HANA) Close() error {
+	h.Lock()
+	dbErr := h.closeDBConn()
+	h.Unlock()
+
+	if err := h.SQLConnectionProducer.Close(); err != nil {
 		return err
 	}
 
-	newdbplugin.Serve(dbType.(newdbplugin.Database), api.VaultPluginTLSProvider(apiTLSConfig))
+	return dbErr
+}
+
+// applyRoleDefaults reads the connection-level user_type, roles, and password_policy defaults out of config.
+func (h *HANA) applyRoleDefaults(config map[string]interface{}) {
+	if userType, ok := config["default_user_type"].(string); ok && userType != "" {
+		h.defaultUserType = userType
+	}
+
+	if policy, ok := config["password_policy"].(string); ok && policy != "" {
+		h.passwordPolicy = policy
+	}
+
+	if roles, ok := config["default_roles"].([]string); ok {
+		h.defaultRoles = roles
+		return
+	}
+	if rolesRaw, ok := config["default_roles"].([]interface{}); ok {
+		roles := make([]string, 0, len(rolesRaw))
+		for _, r := range rolesRaw {
+			if s, ok := r.(string); ok {
+				roles = append(roles, s)
+			}
+		}
+		h.defaultRoles = roles
+	}
+}
+
+// openCertConnection builds a *sql.DB from go-hdb's dedicated auth connectors (verify these
+// signatures against the go-hdb version pinned in go.mod before relying on this), bypassing
+// SQLConnectionProducer's password-DSN path for operators who don't want Vault to ever hold
+// a HANA password.
+func (h *HANA) openCertConnection(config map[string]interface{}, authMethod string) (*sql.DB, error) {
+	host, ok := config["connection_url"].(string)
+	if !ok || host == "" {
+		host, _ = config["host"].(string)
+	}
+	if host == "" {
+		return nil, errors.New("connection_url (or host) is required")
+	}
+
+	var connector *driver.Connector
+
+	switch authMethod {
+	case authMethodX509:
+		certPEM, _ := config["tls_certificate_pem"].(string)
+		keyPEM, _ := config["tls_private_key_pem"].(string)
+		if certPEM == "" || keyPEM == "" {
+			return nil, errors.New("tls_certificate_pem and tls_private_key_pem are required for x509 auth")
+		}
+
+		cert, err := tls.X509KeyPair([]byte(certPEM), []byte(keyPEM))
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse client certificate: %w", err)
+		}
+
+		connector = driver.NewX509AuthConnector(host, cert)
+
+	case authMethodJWT:
+		token, _ := config["jwt"].(string)
+		if token == "" {
+			return nil, errors.New("jwt is required for jwt auth")
+		}
+
+		connector = driver.NewJWTAuthConnector(host, token)
+
+	default:
+		return nil, fmt.Errorf("unsupported auth_method %q", authMethod)
+	}
+
+	if caPEM, ok := config["tls_ca_pem"].(string); ok && caPEM != "" {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(caPEM)) {
+			return nil, errors.New("unable to parse tls_ca_pem")
+		}
+		connector.SetTLSConfig(&tls.Config{RootCAs: pool})
+	}
+
+	return sql.OpenDB(connector), nil
+}
+
+// Run instantiates a HANA object and serves it, multiplexed, as the v5 dbplugin protocol requires.
+func Run() error {
+	dbplugin.ServeMultiplex(New)
 
 	return nil
 }
@@ -81,7 +287,93 @@ func (h *HANA) Type() (string, error) {
 	return hanaTypeName, nil
 }
 
+// RotateRootCredentials generates a new root password, applies it via rotateStatements
+// (default: a plain ALTER USER), and reconnects before returning the updated config.
+func (h *HANA) RotateRootCredentials(ctx context.Context, statements []string) (map[string]interface{}, error) {
+	h.Lock()
+	defer h.Unlock()
+
+	if len(h.Username) == 0 || len(h.Password) == 0 {
+		return nil, errors.New("unable to rotate root credentials: no username or password currently set")
+	}
+
+	rotateStatements := statements
+	if len(rotateStatements) == 0 {
+		rotateStatements = []string{defaultRotateRootCredentialsSQL}
+	}
+
+	db, err := h.getConnection(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get connection: %w", err)
+	}
+
+	newPassword, err := credsutil.RandomAlphaNumeric(20, true)
+	if err != nil {
+		return nil, fmt.Errorf("unable to generate new password: %w", err)
+	}
+	// HANA does not allow hyphens in passwords used via this driver
+	newPassword = strings.Replace(newPassword, "-", "_", -1)
+
+	for _, stmt := range rotateStatements {
+		for _, query := range strutil.ParseArbitraryStringSlice(stmt, ";") {
+			query = strings.TrimSpace(query)
+			if len(query) == 0 {
+				continue
+			}
+
+			m := map[string]string{
+				"name":     h.Username,
+				"username": h.Username,
+				"password": newPassword,
+			}
+
+			if err := dbtxn.ExecuteDBQuery(ctx, db, m, query); err != nil {
+				return nil, fmt.Errorf("failed to execute rotation statement: %w", err)
+			}
+		}
+	}
+
+	h.RawConfig["password"] = newPassword
+	h.Password = newPassword
+	if rawURL, ok := h.RawConfig["connection_url"].(string); ok {
+		h.ConnectionURL = dbutil.QueryHelper(rawURL, map[string]string{
+			"username": url.PathEscape(h.Username),
+			"password": url.PathEscape(newPassword),
+		})
+	}
+
+	// Close the pool using the old credentials and reconnect with the new ones so a failed
+	// write of the returned config doesn't leave Vault holding a connection HANA has already
+	// invalidated. This method already holds h.Lock(), so it can't call h.Close() directly:
+	// that (and the SQLConnectionProducer.Close it delegates to) takes the same lock
+	// internally. Release it for just that call.
+	dbErr := h.closeDBConn()
+	h.Unlock()
+	producerErr := h.SQLConnectionProducer.Close()
+	h.Lock()
+
+	if dbErr != nil {
+		return nil, fmt.Errorf("unable to close connection using old credentials: %w", dbErr)
+	}
+	if producerErr != nil {
+		return nil, fmt.Errorf("unable to close connection using old credentials: %w", producerErr)
+	}
+
+	if _, err := h.getConnection(ctx); err != nil {
+		return nil, fmt.Errorf("unable to reconnect using new credentials: %w", err)
+	}
+
+	return h.RawConfig, nil
+}
+
 func (h *HANA) getConnection(ctx context.Context) (*sql.DB, error) {
+	if h.authMethod != "" && h.authMethod != authMethodPassword {
+		if h.db == nil {
+			return nil, errors.New("no certificate-based connection established")
+		}
+		return h.db, nil
+	}
+
 	db, err := h.Connection(ctx)
 	if err != nil {
 		return nil, err
@@ -92,7 +384,7 @@ func (h *HANA) getConnection(ctx context.Context) (*sql.DB, error) {
 
 // CreateUser generates the username/password on the underlying HANA secret backend
 // as instructed by the CreationStatement provided.
-func (h *HANA) NewUser(ctx context.Context, req newdbplugin.NewUserRequest) (response newdbplugin.NewUserResponse, err error) {
+func (h *HANA) NewUser(ctx context.Context, req dbplugin.NewUserRequest) (response dbplugin.NewUserResponse, err error) {
 	// Grab the lock
 	h.Lock()
 	defer h.Unlock()
@@ -100,11 +392,29 @@ func (h *HANA) NewUser(ctx context.Context, req newdbplugin.NewUserRequest) (res
 	// Get the connection
 	db, err := h.getConnection(ctx)
 	if err != nil {
-		return newdbplugin.NewUserResponse{}, err
+		return dbplugin.NewUserResponse{}, err
 	}
 
-	if len(req.Statements.Commands) == 0 {
-		return newdbplugin.NewUserResponse{}, dbutil.ErrEmptyCreationStatement
+	userType := h.defaultUserType
+	if userType == "" {
+		userType = userTypeStandard
+	}
+
+	commands := req.Statements.Commands
+	if len(commands) > 0 && strings.HasPrefix(commands[0], userTypePreamblePrefix) {
+		userType = strings.TrimSpace(strings.TrimPrefix(commands[0], userTypePreamblePrefix))
+		commands = commands[1:]
+	}
+
+	if userType == userTypeRestricted {
+		// The operator's remaining commands are just GRANTs; we own the CREATE statement.
+		commands = append([]string{defaultRestrictedUserCreationSQL}, commands...)
+	}
+
+	// Check only after stripping the preamble and applying the restricted-mode prepend: a
+	// role whose Commands is just the preamble (no real SQL) must not look like success.
+	if len(commands) == 0 {
+		return dbplugin.NewUserResponse{}, dbutil.ErrEmptyCreationStatement
 	}
 
 	dispName := credsutil.DisplayName(req.UsernameConfig.DisplayName, 32)
@@ -116,7 +426,7 @@ func (h *HANA) NewUser(ctx context.Context, req newdbplugin.NewUserRequest) (res
 	// Generate username
 	username, err := credsutil.GenerateUsername(dispName, roleName, maxLen, separator, caps)
 	if err != nil {
-		return newdbplugin.NewUserResponse{}, err
+		return dbplugin.NewUserResponse{}, err
 	}
 
 	// HANA does not allow hyphens in usernames, and highly prefers capital letters
@@ -132,15 +442,17 @@ func (h *HANA) NewUser(ctx context.Context, req newdbplugin.NewUserRequest) (res
 	// regardless of whether vault is alive to revoke lease
 	expirationStr := req.Expiration.UTC().Format("2006-01-02 15:04:05")
 
+	rolesStr := strings.Join(h.defaultRoles, ", ")
+
 	// Start a transaction
 	tx, err := db.BeginTx(ctx, nil)
 	if err != nil {
-		return newdbplugin.NewUserResponse{}, err
+		return dbplugin.NewUserResponse{}, err
 	}
 	defer tx.Rollback()
 
 	// Execute each query
-	for _, stmt := range req.Statements.Commands {
+	for _, stmt := range commands {
 		for _, query := range strutil.ParseArbitraryStringSlice(stmt, ";") {
 			query = strings.TrimSpace(query)
 			if len(query) == 0 {
@@ -151,20 +463,21 @@ func (h *HANA) NewUser(ctx context.Context, req newdbplugin.NewUserRequest) (res
 				"name":       username,
 				"password":   password,
 				"expiration": expirationStr,
+				"roles":      rolesStr,
 			}
 
 			if err := dbtxn.ExecuteTxQuery(ctx, tx, m, query); err != nil {
-				return newdbplugin.NewUserResponse{}, err
+				return dbplugin.NewUserResponse{}, err
 			}
 		}
 	}
 
 	// Commit the transaction
 	if err := tx.Commit(); err != nil {
-		return newdbplugin.NewUserResponse{}, err
+		return dbplugin.NewUserResponse{}, err
 	}
 
-	resp := newdbplugin.NewUserResponse{
+	resp := dbplugin.NewUserResponse{
 		Username: username,
 	}
 
@@ -172,51 +485,114 @@ func (h *HANA) NewUser(ctx context.Context, req newdbplugin.NewUserRequest) (res
 }
 
 // Renewing hana user just means altering user's valid until property
-func (h *HANA) UpdateUser(ctx context.Context, req newdbplugin.UpdateUserRequest) (newdbplugin.UpdateUserResponse, error) {
+func (h *HANA) UpdateUser(ctx context.Context, req dbplugin.UpdateUserRequest) (dbplugin.UpdateUserResponse, error) {
 	h.Lock()
 	defer h.Unlock()
 
 	// No change requested
 	if req.Password == nil && req.Expiration == nil {
-		return newdbplugin.UpdateUserResponse{}, nil
+		return dbplugin.UpdateUserResponse{}, nil
 	}
 
 	// Get connection
 	db, err := h.getConnection(ctx)
 	if err != nil {
-		return newdbplugin.UpdateUserResponse{}, err
+		return dbplugin.UpdateUserResponse{}, err
 	}
 
 	// Start a transaction
 	tx, err := db.BeginTx(ctx, nil)
 	if err != nil {
-		return newdbplugin.UpdateUserResponse{}, err
+		return dbplugin.UpdateUserResponse{}, err
 	}
 	defer tx.Rollback()
 
 	if req.Password != nil {
+		// A static-role rotation carries a Password with no accompanying Expiration change.
+		if req.Expiration == nil {
+			if err := h.verifyStaticUser(ctx, db, req.Username); err != nil {
+				return dbplugin.UpdateUserResponse{}, err
+			}
+
+			// When no password_policy is configured, Vault has no policy to enforce
+			// HANA's complexity rules, so fall back to a baseline check here.
+			if h.passwordPolicy == "" {
+				if err := checkPasswordPolicy(req.Password.NewPassword); err != nil {
+					return dbplugin.UpdateUserResponse{}, err
+				}
+			}
+		}
+
 		err = h.updateUserPassword(ctx, tx, req.Username, req.Password)
 		if err != nil {
-			return newdbplugin.UpdateUserResponse{}, err
+			return dbplugin.UpdateUserResponse{}, err
 		}
 	}
 
 	if req.Expiration != nil {
 		err = h.updateUserExpiration(ctx, tx, req.Username, req.Expiration)
 		if err != nil {
-			return newdbplugin.UpdateUserResponse{}, err
+			return dbplugin.UpdateUserResponse{}, err
 		}
 	}
 
 	// Commit the transaction
 	if err := tx.Commit(); err != nil {
-		return newdbplugin.UpdateUserResponse{}, err
+		return dbplugin.UpdateUserResponse{}, err
+	}
+
+	return dbplugin.UpdateUserResponse{}, nil
+}
+
+// checkPasswordPolicy is a baseline complexity check for static-role rotations that don't
+// configure a Vault password_policy.
+func checkPasswordPolicy(password string) error {
+	if len(password) < minStaticRolePasswordLength {
+		return fmt.Errorf("password does not meet minimum length of %d", minStaticRolePasswordLength)
+	}
+
+	var hasUpper, hasLower, hasDigit bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		}
+	}
+	if !hasUpper || !hasLower || !hasDigit {
+		return errors.New("password must contain upper-case, lower-case, and numeric characters")
 	}
 
-	return newdbplugin.UpdateUserResponse{}, nil
+	return nil
+}
+
+// verifyStaticUser confirms username is a real HANA user and not one of NewUser's dynamic,
+// lease-scoped users.
+func (h *HANA) verifyStaticUser(ctx context.Context, db *sql.DB, username string) error {
+	if username == "" {
+		return errors.New("must provide a username to verify")
+	}
+
+	if strings.HasPrefix(strings.ToUpper(username), dynamicUsernamePrefix) {
+		return fmt.Errorf("refusing to rotate %q: it looks like a Vault-managed dynamic user, not a static role", username)
+	}
+
+	var count int
+	row := db.QueryRowContext(ctx, "SELECT COUNT(*) FROM SYS.USERS WHERE USER_NAME = ?", strings.ToUpper(username))
+	if err := row.Scan(&count); err != nil {
+		return fmt.Errorf("unable to verify user %q exists: %w", username, err)
+	}
+	if count == 0 {
+		return fmt.Errorf("user %q does not exist", username)
+	}
+
+	return nil
 }
 
-func (h *HANA) updateUserPassword(ctx context.Context, tx *sql.Tx, username string, req *newdbplugin.ChangePassword) error {
+func (h *HANA) updateUserPassword(ctx context.Context, tx *sql.Tx, username string, req *dbplugin.ChangePassword) error {
 	password := req.NewPassword
 
 	if username == "" || password == "" {
@@ -250,7 +626,7 @@ func (h *HANA) updateUserPassword(ctx context.Context, tx *sql.Tx, username stri
 	return nil
 }
 
-func (h *HANA) updateUserExpiration(ctx context.Context, tx *sql.Tx, username string, req *newdbplugin.ChangeExpiration) error {
+func (h *HANA) updateUserExpiration(ctx context.Context, tx *sql.Tx, username string, req *dbplugin.ChangeExpiration) error {
 	// If expiration is in the role SQL, HANA will deactivate the user when time is up,
 	// regardless of whether vault is alive to revoke lease
 	expirationStr := req.NewExpiration.String()
@@ -287,7 +663,7 @@ func (h *HANA) updateUserExpiration(ctx context.Context, tx *sql.Tx, username st
 }
 
 // Revoking hana user will deactivate user and try to perform a soft drop
-func (h *HANA) DeleteUser(ctx context.Context, req newdbplugin.DeleteUserRequest) (newdbplugin.DeleteUserResponse, error) {
+func (h *HANA) DeleteUser(ctx context.Context, req dbplugin.DeleteUserRequest) (dbplugin.DeleteUserResponse, error) {
 	h.Lock()
 	h.Unlock()
 
@@ -299,13 +675,13 @@ func (h *HANA) DeleteUser(ctx context.Context, req newdbplugin.DeleteUserRequest
 	// Get connection
 	db, err := h.getConnection(ctx)
 	if err != nil {
-		return newdbplugin.DeleteUserResponse{}, err
+		return dbplugin.DeleteUserResponse{}, err
 	}
 
 	// Start a transaction
 	tx, err := db.BeginTx(ctx, nil)
 	if err != nil {
-		return newdbplugin.DeleteUserResponse{}, err
+		return dbplugin.DeleteUserResponse{}, err
 	}
 	defer tx.Rollback()
 
@@ -321,53 +697,54 @@ func (h *HANA) DeleteUser(ctx context.Context, req newdbplugin.DeleteUserRequest
 				"name": req.Username,
 			}
 			if err := dbtxn.ExecuteTxQuery(ctx, tx, m, query); err != nil {
-				return newdbplugin.DeleteUserResponse{}, err
+				return dbplugin.DeleteUserResponse{}, err
 			}
 		}
 	}
 
-	return newdbplugin.DeleteUserResponse{}, tx.Commit()
+	return dbplugin.DeleteUserResponse{}, tx.Commit()
 }
 
-func (h *HANA) revokeUserDefault(ctx context.Context, req newdbplugin.DeleteUserRequest) (newdbplugin.DeleteUserResponse, error) {
+// revokeUserDefault's DEACTIVATE + soft-DROP statements apply equally to restricted users.
+func (h *HANA) revokeUserDefault(ctx context.Context, req dbplugin.DeleteUserRequest) (dbplugin.DeleteUserResponse, error) {
 	// Get connection
 	db, err := h.getConnection(ctx)
 	if err != nil {
-		return newdbplugin.DeleteUserResponse{}, err
+		return dbplugin.DeleteUserResponse{}, err
 	}
 
 	// Start a transaction
 	tx, err := db.BeginTx(ctx, nil)
 	if err != nil {
-		return newdbplugin.DeleteUserResponse{}, err
+		return dbplugin.DeleteUserResponse{}, err
 	}
 	defer tx.Rollback()
 
 	// Disable server login for user
 	disableStmt, err := tx.PrepareContext(ctx, fmt.Sprintf("ALTER USER %s DEACTIVATE USER NOW", req.Username))
 	if err != nil {
-		return newdbplugin.DeleteUserResponse{}, err
+		return dbplugin.DeleteUserResponse{}, err
 	}
 	defer disableStmt.Close()
 	if _, err := disableStmt.ExecContext(ctx); err != nil {
-		return newdbplugin.DeleteUserResponse{}, err
+		return dbplugin.DeleteUserResponse{}, err
 	}
 
 	// Invalidates current sessions and performs soft drop (drop if no dependencies)
 	// if hard drop is desired, custom revoke statements should be written for role
 	dropStmt, err := tx.PrepareContext(ctx, fmt.Sprintf("DROP USER %s RESTRICT", req.Username))
 	if err != nil {
-		return newdbplugin.DeleteUserResponse{}, err
+		return dbplugin.DeleteUserResponse{}, err
 	}
 	defer dropStmt.Close()
 	if _, err := dropStmt.ExecContext(ctx); err != nil {
-		return newdbplugin.DeleteUserResponse{}, err
+		return dbplugin.DeleteUserResponse{}, err
 	}
 
 	// Commit transaction
 	if err := tx.Commit(); err != nil {
-		return newdbplugin.DeleteUserResponse{}, err
+		return dbplugin.DeleteUserResponse{}, err
 	}
 
-	return newdbplugin.DeleteUserResponse{}, nil
+	return dbplugin.DeleteUserResponse{}, nil
 }
@@ -0,0 +1,142 @@
+package hana
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/vault/sdk/database/dbplugin/v5"
+	"github.com/hashicorp/vault/sdk/database/helper/credsutil"
+)
+
+// getHANAConnectionURL returns a go-hdb DSN for a real HANA instance, taken from the
+// environment. HANA has no redistributable Docker image, so these tests run against
+// whatever instance HANA_URL points at and are skipped otherwise. The DSN carries
+// {{username}} and {{password}} placeholders; testHANA fills them from HANA_USERNAME
+// and HANA_PASSWORD.
+func getHANAConnectionURL(t *testing.T) string {
+	t.Helper()
+
+	connURL := os.Getenv("HANA_URL")
+	if connURL == "" {
+		t.Skip("HANA_URL not set, skipping HANA integration test")
+	}
+	return connURL
+}
+
+func testHANA(t *testing.T) *HANA {
+	t.Helper()
+
+	config := map[string]interface{}{
+		"connection_url": getHANAConnectionURL(t),
+		"username":       os.Getenv("HANA_USERNAME"),
+		"password":       os.Getenv("HANA_PASSWORD"),
+	}
+
+	db := new()
+	if _, err := db.Init(context.Background(), config, true); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	return db
+}
+
+func TestHANA_RotateRootCredentials(t *testing.T) {
+	db := testHANA(t)
+	defer db.Close()
+
+	oldPassword := db.Password
+
+	newConfig, err := db.RotateRootCredentials(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if newConfig["password"] == oldPassword {
+		t.Fatal("expected password to change")
+	}
+	if newConfig["password"] != db.Password {
+		t.Fatal("expected returned config password to match the connection's password")
+	}
+
+	reconnect := new()
+	if _, err := reconnect.Init(context.Background(), newConfig, true); err != nil {
+		t.Fatalf("unable to connect with rotated root password: %s", err)
+	}
+	defer reconnect.Close()
+}
+
+func TestHANA_RotateRootCredentials_CustomStatements(t *testing.T) {
+	db := testHANA(t)
+	defer db.Close()
+
+	oldPassword := db.Password
+
+	newConfig, err := db.RotateRootCredentials(context.Background(), []string{defaultRotateRootCredentialsSQL})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if newConfig["password"] == oldPassword {
+		t.Fatal("expected password to change")
+	}
+}
+
+// TestHANA_UpdateUser_StaticRolePassword rotates a fixed, pre-existing user's password
+// repeatedly and confirms a login works with each new password. HANA_STATIC_USER must
+// already exist in the target instance.
+func TestHANA_UpdateUser_StaticRolePassword(t *testing.T) {
+	db := testHANA(t)
+	defer db.Close()
+
+	username := os.Getenv("HANA_STATIC_USER")
+	if username == "" {
+		t.Skip("HANA_STATIC_USER not set, skipping static role rotation test")
+	}
+
+	for i := 0; i < 3; i++ {
+		newPassword, err := credsutil.RandomAlphaNumeric(20, true)
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+		newPassword = strings.Replace(newPassword, "-", "_", -1)
+
+		_, err = db.UpdateUser(context.Background(), dbplugin.UpdateUserRequest{
+			Username: username,
+			Password: &dbplugin.ChangePassword{NewPassword: newPassword},
+		})
+		if err != nil {
+			t.Fatalf("rotation %d: err: %s", i, err)
+		}
+
+		loginConfig := map[string]interface{}{
+			"connection_url": getHANAConnectionURL(t),
+			"username":       username,
+			"password":       newPassword,
+		}
+
+		login := new()
+		if _, err := login.Init(context.Background(), loginConfig, true); err != nil {
+			t.Fatalf("rotation %d: unable to log in with new password: %s", i, err)
+		}
+		login.Close()
+	}
+}
+
+func TestCheckPasswordPolicy(t *testing.T) {
+	cases := map[string]bool{
+		"short1A":       false,
+		"nouppercase1":  false,
+		"NOLOWERCASE1":  false,
+		"NoDigitsHere":  false,
+		"ValidPass123_": true,
+	}
+
+	for password, wantOK := range cases {
+		err := checkPasswordPolicy(password)
+		if (err == nil) != wantOK {
+			t.Errorf("checkPasswordPolicy(%q): got err=%v, want ok=%v", password, err, wantOK)
+		}
+	}
+}